@@ -0,0 +1,166 @@
+package got
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// catalogFile is the on-disk shape of a catalog file (odcinki.yaml/json),
+// decoded before being converted to the public Catalog/RouteSegment types.
+type catalogFile struct {
+	Segments []catalogSegmentFile `json:"segments"`
+}
+
+type catalogSegmentFile struct {
+	Name    string          `json:"name"`
+	Terrain string          `json:"terrain"`
+	Points  int             `json:"points"`
+	Track   []catalogLatLon `json:"track"`
+}
+
+type catalogLatLon struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// LoadCatalog reads an official GOT route catalog from a JSON or YAML file
+// (selected by extension) and converts it to a Catalog for use with Score.
+func LoadCatalog(path string) (Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Catalog{}, fmt.Errorf("reading catalog %s: %w", path, err)
+	}
+
+	var cf catalogFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &cf); err != nil {
+			return Catalog{}, fmt.Errorf("parsing JSON catalog %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		cf, err = parseCatalogYAML(data)
+		if err != nil {
+			return Catalog{}, fmt.Errorf("parsing YAML catalog %s: %w", path, err)
+		}
+	default:
+		return Catalog{}, fmt.Errorf("unsupported catalog format %q (want .json, .yaml, or .yml)", ext)
+	}
+
+	catalog := Catalog{Segments: make([]RouteSegment, len(cf.Segments))}
+	for i, s := range cf.Segments {
+		track := make([]Point, len(s.Track))
+		for j, t := range s.Track {
+			track[j] = Point{Lat: t.Lat, Lon: t.Lon}
+		}
+		catalog.Segments[i] = RouteSegment{
+			Name:    s.Name,
+			Terrain: Terrain(s.Terrain),
+			Points:  s.Points,
+			Track:   track,
+		}
+	}
+	return catalog, nil
+}
+
+// parseCatalogYAML is a minimal decoder for this package's catalog schema
+// only (a "segments:" list of name/terrain/points/track entries, track
+// points in flow-map style "- {lat: X, lon: Y}"). It is not a general-purpose
+// YAML parser; the repo has no YAML dependency, so this covers the one
+// shape odcinki.yaml actually needs.
+func parseCatalogYAML(data []byte) (catalogFile, error) {
+	var cf catalogFile
+	var cur *catalogSegmentFile
+	inTrack := false
+
+	flush := func() {
+		if cur != nil {
+			cf.Segments = append(cf.Segments, *cur)
+		}
+	}
+
+	for _, rawLine := range strings.Split(string(data), "\n") {
+		line := rawLine
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "segments:" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "- name:"):
+			flush()
+			cur = &catalogSegmentFile{Name: unquote(strings.TrimSpace(strings.TrimPrefix(trimmed, "- name:")))}
+			inTrack = false
+
+		case strings.HasPrefix(trimmed, "terrain:"):
+			if cur != nil {
+				cur.Terrain = unquote(strings.TrimSpace(strings.TrimPrefix(trimmed, "terrain:")))
+			}
+			inTrack = false
+
+		case strings.HasPrefix(trimmed, "points:"):
+			if cur == nil {
+				return cf, fmt.Errorf("\"points:\" with no preceding segment")
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(trimmed, "points:")))
+			if err != nil {
+				return cf, fmt.Errorf("invalid points value on line %q: %w", trimmed, err)
+			}
+			cur.Points = n
+			inTrack = false
+
+		case trimmed == "track:":
+			inTrack = true
+
+		case inTrack && strings.HasPrefix(trimmed, "-"):
+			if cur == nil {
+				return cf, fmt.Errorf("track point with no preceding segment")
+			}
+			pt, err := parseFlowLatLon(trimmed)
+			if err != nil {
+				return cf, err
+			}
+			cur.Track = append(cur.Track, pt)
+		}
+	}
+	flush()
+
+	return cf, nil
+}
+
+// parseFlowLatLon parses a single "- {lat: 49.23, lon: 20.01}" track entry.
+func parseFlowLatLon(line string) (catalogLatLon, error) {
+	var pt catalogLatLon
+
+	line = strings.TrimSpace(strings.TrimPrefix(line, "-"))
+	line = strings.TrimPrefix(strings.TrimSpace(line), "{")
+	line = strings.TrimSuffix(strings.TrimSpace(line), "}")
+
+	for _, field := range strings.Split(line, ",") {
+		kv := strings.SplitN(field, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		val, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			return pt, fmt.Errorf("invalid coordinate in %q: %w", line, err)
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "lat":
+			pt.Lat = val
+		case "lon":
+			pt.Lon = val
+		}
+	}
+	return pt, nil
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"'`)
+}
@@ -0,0 +1,107 @@
+package got
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCatalogFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing fixture %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadCatalogJSON(t *testing.T) {
+	path := writeCatalogFile(t, "odcinki.json", `{
+		"segments": [
+			{
+				"name": "Morskie Oko - Dolina Pieciu Stawow",
+				"terrain": "tatry",
+				"points": 12,
+				"track": [{"lat": 49.20, "lon": 20.07}, {"lat": 49.21, "lon": 20.08}]
+			}
+		]
+	}`)
+
+	catalog, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog: %v", err)
+	}
+	if len(catalog.Segments) != 1 {
+		t.Fatalf("got %d segments, want 1", len(catalog.Segments))
+	}
+	seg := catalog.Segments[0]
+	if seg.Name != "Morskie Oko - Dolina Pieciu Stawow" || seg.Terrain != TerrainTatry || seg.Points != 12 {
+		t.Errorf("unexpected segment: %+v", seg)
+	}
+	if len(seg.Track) != 2 || seg.Track[0].Lat != 49.20 || seg.Track[1].Lon != 20.08 {
+		t.Errorf("unexpected track: %+v", seg.Track)
+	}
+}
+
+func TestLoadCatalogYAML(t *testing.T) {
+	path := writeCatalogFile(t, "odcinki.yaml", `segments:
+  - name: "Babia Gora grzbietem"
+    terrain: beskidy
+    points: 8
+    track:
+      - {lat: 49.57, lon: 19.52}
+      - {lat: 49.58, lon: 19.53}
+  - name: Snieznik
+    terrain: sudety
+    points: 6
+    track:
+      - {lat: 50.20, lon: 16.85}
+`)
+
+	catalog, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatalf("LoadCatalog: %v", err)
+	}
+	if len(catalog.Segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(catalog.Segments))
+	}
+	if catalog.Segments[0].Name != "Babia Gora grzbietem" || catalog.Segments[0].Terrain != TerrainBeskidy {
+		t.Errorf("unexpected first segment: %+v", catalog.Segments[0])
+	}
+	if len(catalog.Segments[0].Track) != 2 {
+		t.Errorf("got %d track points in first segment, want 2", len(catalog.Segments[0].Track))
+	}
+	if catalog.Segments[1].Name != "Snieznik" || catalog.Segments[1].Points != 6 {
+		t.Errorf("unexpected second segment: %+v", catalog.Segments[1])
+	}
+}
+
+func TestLoadCatalogUnsupportedExtension(t *testing.T) {
+	path := writeCatalogFile(t, "odcinki.txt", "segments: []")
+
+	if _, err := LoadCatalog(path); err == nil {
+		t.Fatal("expected an error for an unsupported catalog extension, got nil")
+	}
+}
+
+func TestLoadCatalogYAMLTrackPointWithNoSegment(t *testing.T) {
+	path := writeCatalogFile(t, "odcinki.yaml", `segments:
+  track:
+    - {lat: 49.57, lon: 19.52}
+`)
+
+	if _, err := LoadCatalog(path); err == nil {
+		t.Fatal("expected an error for a track point with no preceding segment, got nil")
+	}
+}
+
+func TestLoadCatalogYAMLInvalidPoints(t *testing.T) {
+	path := writeCatalogFile(t, "odcinki.yaml", `segments:
+  - name: Bad Segment
+    points: not-a-number
+`)
+
+	if _, err := LoadCatalog(path); err == nil {
+		t.Fatal("expected an error for a non-numeric points value, got nil")
+	}
+}
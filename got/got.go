@@ -0,0 +1,253 @@
+// Package got implements the PTTK Górska Odznaka Turystyczna (GOT) scoring
+// rules: terrain-aware points per kilometer/ascent, the real per-day badge
+// caps, and matching a recorded track against a catalog of official route
+// segments ("odcinki") that award fixed points instead of a GPS-derived
+// estimate.
+package got
+
+import "math"
+
+// Point is a single lat/lon/elevation sample of a track or catalog segment.
+type Point struct {
+	Lat float64
+	Lon float64
+	Ele float64
+}
+
+// Track is the polyline a day's hike produced, in the order it was walked.
+type Track struct {
+	Points []Point
+}
+
+// Terrain selects the points-per-km/ascent multipliers for a region, since
+// PTTK awards points differently in the Tatras than on lowland routes.
+type Terrain string
+
+const (
+	TerrainTatry   Terrain = "tatry"
+	TerrainBeskidy Terrain = "beskidy"
+	TerrainSudety  Terrain = "sudety"
+	TerrainNizinne Terrain = "nizinne"
+
+	defaultTerrain Terrain = TerrainBeskidy
+)
+
+type terrainRule struct {
+	PointsPerKM         float64
+	PointsPer100mAscent float64
+}
+
+// terrainRules holds the points-per-km/ascent multipliers per region. Tatry
+// routes are steeper and more exposed, so ascent is weighted higher; nizinne
+// (lowland) routes are weighted down since they rarely gain real elevation.
+var terrainRules = map[Terrain]terrainRule{
+	TerrainTatry:   {PointsPerKM: 1.0, PointsPer100mAscent: 1.5},
+	TerrainBeskidy: {PointsPerKM: 1.0, PointsPer100mAscent: 1.0},
+	TerrainSudety:  {PointsPerKM: 1.0, PointsPer100mAscent: 1.0},
+	TerrainNizinne: {PointsPerKM: 0.5, PointsPer100mAscent: 0.5},
+}
+
+// BadgeTier is one of the GOT badge levels, each with its own daily point
+// cap (a hiker can't max out the "mała brązowa" badge from a single huge day).
+type BadgeTier string
+
+const (
+	TierPopularna BadgeTier = "popularna"
+	TierMala      BadgeTier = "mala"
+	TierDuza      BadgeTier = "duza"
+)
+
+// dailyCap is the maximum number of points a single day can contribute
+// towards a given badge tier. Popularna and mała brązowa are entry-level
+// badges with lower requirements overall, so PTTK caps a single day's
+// contribution lower too; duża and above use the full 50 pt/day cap.
+var dailyCap = map[BadgeTier]int{
+	TierPopularna: 30,
+	TierMala:      40,
+	TierDuza:      50,
+}
+
+// defaultDailyCap applies when Options.Tier is empty or unrecognized.
+const defaultDailyCap = 50
+
+// tripCap is the maximum number of points a single multi-day trip (an
+// unbroken run of consecutive hiking days, as opposed to a season's worth
+// of scattered outings) can contribute towards a given badge tier. Without
+// it, one long trip could single-handedly fill an entire badge, which runs
+// against the GOT's intent of rewarding hiking spread across a season.
+var tripCap = map[BadgeTier]int{
+	TierPopularna: 60,
+	TierMala:      90,
+	TierDuza:      150,
+}
+
+// defaultTripCap applies when Options.Tier is empty or unrecognized.
+const defaultTripCap = 150
+
+// RouteSegment is one official, pre-scored route ("odcinek") from the GOT
+// catalog. Matching a recorded track to a segment awards Points directly
+// instead of recomputing from GPS.
+type RouteSegment struct {
+	Name    string
+	Terrain Terrain
+	Points  int
+	Track   []Point
+}
+
+// Catalog is the full set of official route segments loaded via LoadCatalog.
+type Catalog struct {
+	Segments []RouteSegment
+}
+
+// Options configures a single Score call.
+type Options struct {
+	Terrain Terrain
+	Tier    BadgeTier
+	// MatchThresholdMeters is the maximum distance, in meters, within which
+	// a track is considered to follow a catalog segment: discrete Fréchet
+	// distance, or Hausdorff distance for point sets past frechetPointBudget.
+	MatchThresholdMeters float64
+}
+
+// DailyScore is the result of scoring one day's track against the GOT rules.
+type DailyScore struct {
+	DistanceKM     float64
+	AscentM        float64
+	DistancePoints int
+	AscentPoints   int
+	// MatchedSegment and UsedCatalog are set when the track snapped to a
+	// catalog route segment; in that case RawPoints/Points come from the
+	// segment's codified award rather than DistancePoints+AscentPoints.
+	MatchedSegment string
+	UsedCatalog    bool
+	RawPoints      int
+	Points         int
+	DailyCap       int
+	Capped         bool
+	// TripCapped is set by ApplyTripCap when a trip's running total pushed
+	// this day's points below what the daily cap alone would have allowed.
+	TripCapped bool
+}
+
+// Score computes a day's GOT points for track against the terrain/tier rules
+// in opts, preferring a catalog segment within opts.MatchThresholdMeters
+// over recomputing from GPS.
+func Score(track Track, catalog Catalog, opts Options) DailyScore {
+	distanceKM := trackLengthKM(track.Points)
+	ascentM := trackAscentM(track.Points)
+
+	rule, ok := terrainRules[opts.Terrain]
+	if !ok {
+		rule = terrainRules[defaultTerrain]
+	}
+
+	distancePoints := int(math.Round(distanceKM * rule.PointsPerKM))
+	ascentPoints := int(math.Round(ascentM / 100.0 * rule.PointsPer100mAscent))
+
+	raw := distancePoints + ascentPoints
+	matchedName := ""
+	usedCatalog := false
+
+	if seg, ok := matchCatalog(track.Points, catalog, opts.MatchThresholdMeters); ok {
+		raw = seg.Points
+		matchedName = seg.Name
+		usedCatalog = true
+	}
+
+	cap := dailyCap[opts.Tier]
+	if cap == 0 {
+		cap = defaultDailyCap
+	}
+
+	points := raw
+	capped := false
+	if points > cap {
+		points = cap
+		capped = true
+	}
+
+	return DailyScore{
+		DistanceKM:     distanceKM,
+		AscentM:        ascentM,
+		DistancePoints: distancePoints,
+		AscentPoints:   ascentPoints,
+		MatchedSegment: matchedName,
+		UsedCatalog:    usedCatalog,
+		RawPoints:      raw,
+		Points:         points,
+		DailyCap:       cap,
+		Capped:         capped,
+	}
+}
+
+// ApplyTripCap enforces the per-trip point cap for tier across scores, which
+// must already be in the order the days were hiked (a trip is an unbroken
+// run of consecutive days, so order matters: earlier days are credited
+// first, and once the trip cap is reached, later days in the same trip
+// contribute nothing further towards the badge). Each day's own daily cap,
+// already applied by Score, is left untouched by this step; ApplyTripCap
+// only ever reduces a day's Points further, never raises them.
+func ApplyTripCap(scores []DailyScore, tier BadgeTier) []DailyScore {
+	cap := tripCap[tier]
+	if cap == 0 {
+		cap = defaultTripCap
+	}
+
+	out := make([]DailyScore, len(scores))
+	copy(out, scores)
+
+	remaining := cap
+	for i := range out {
+		if out[i].Points > remaining {
+			out[i].Points = remaining
+			out[i].TripCapped = true
+		}
+		remaining -= out[i].Points
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+
+	return out
+}
+
+// trackLengthKM sums the great-circle distance between consecutive points.
+func trackLengthKM(points []Point) float64 {
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		total += haversineMeters(points[i-1], points[i]) / 1000.0
+	}
+	return total
+}
+
+// trackAscentM sums every positive elevation delta between consecutive points.
+func trackAscentM(points []Point) float64 {
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		if diff := points[i].Ele - points[i-1].Ele; diff > 0 {
+			total += diff
+		}
+	}
+	return total
+}
+
+// earthRadiusMeters is the average radius of the Earth in meters.
+const earthRadiusMeters = 6371000.0
+
+func haversineMeters(a, b Point) float64 {
+	lat1, lon1 := toRadians(a.Lat), toRadians(a.Lon)
+	lat2, lon2 := toRadians(b.Lat), toRadians(b.Lon)
+
+	dLat := lat2 - lat1
+	dLon := lon2 - lon1
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	c := 2 * math.Atan2(math.Sqrt(h), math.Sqrt(1-h))
+
+	return earthRadiusMeters * c
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180.0
+}
@@ -0,0 +1,116 @@
+package got
+
+import "testing"
+
+// straightTrack builds a track of n+1 points walking due north, each step
+// stepMeters apart, climbing stepAscent meters of elevation per step, so
+// distance and ascent are easy to reason about in the assertions below.
+func straightTrack(n int, stepMeters, stepAscent float64) Track {
+	const metersPerDegreeLat = 111320.0
+	points := make([]Point, n+1)
+	for i := 0; i <= n; i++ {
+		points[i] = Point{
+			Lat: float64(i) * stepMeters / metersPerDegreeLat,
+			Lon: 19.0,
+			Ele: float64(i) * stepAscent,
+		}
+	}
+	return Track{Points: points}
+}
+
+func TestScoreAppliesTerrainMultiplier(t *testing.T) {
+	// 10 steps of 100m ascent each: 1000m of ascent, 0 distance (flat lon/lat
+	// steps chosen small enough that distance rounds to 0 points), so the
+	// only thing differing between terrains is the ascent multiplier.
+	track := straightTrack(10, 0, 100)
+
+	tatry := Score(track, Catalog{}, Options{Terrain: TerrainTatry})
+	if want := 15; tatry.AscentPoints != want {
+		t.Errorf("Tatry AscentPoints = %d, want %d (1.5 pkt/100m * 1000m)", tatry.AscentPoints, want)
+	}
+
+	beskidy := Score(track, Catalog{}, Options{Terrain: TerrainBeskidy})
+	if want := 10; beskidy.AscentPoints != want {
+		t.Errorf("Beskidy AscentPoints = %d, want %d (1.0 pkt/100m * 1000m)", beskidy.AscentPoints, want)
+	}
+
+	nizinne := Score(track, Catalog{}, Options{Terrain: TerrainNizinne})
+	if want := 5; nizinne.AscentPoints != want {
+		t.Errorf("Nizinne AscentPoints = %d, want %d (0.5 pkt/100m * 1000m)", nizinne.AscentPoints, want)
+	}
+}
+
+func TestScoreAppliesDailyCap(t *testing.T) {
+	// 100 steps of 1km each at 1.0 pkt/km (beskidy) = 100 raw points, well
+	// past even duza's 50pt daily cap.
+	track := straightTrack(100, 1000, 0)
+
+	score := Score(track, Catalog{}, Options{Terrain: TerrainBeskidy, Tier: TierPopularna})
+	if !score.Capped {
+		t.Fatal("expected Capped to be true for a day far exceeding the daily cap")
+	}
+	if score.Points != dailyCap[TierPopularna] {
+		t.Errorf("Points = %d, want daily cap %d", score.Points, dailyCap[TierPopularna])
+	}
+	if score.RawPoints <= score.Points {
+		t.Errorf("RawPoints = %d, should exceed capped Points = %d", score.RawPoints, score.Points)
+	}
+}
+
+func TestApplyTripCapCapsLaterDays(t *testing.T) {
+	// Each day scores 40 points uncapped by its own daily cap (duza: 50/day),
+	// but duza's trip cap is 150, so three 40pt days (120 total) fit, and a
+	// fourth pushes the trip over: only 30 of its 40 points should count.
+	scores := []DailyScore{
+		{Points: 40},
+		{Points: 40},
+		{Points: 40},
+		{Points: 40},
+	}
+
+	capped := ApplyTripCap(scores, TierDuza)
+
+	for i := 0; i < 3; i++ {
+		if capped[i].Points != 40 || capped[i].TripCapped {
+			t.Errorf("day %d = %+v, want 40 points and not trip-capped", i, capped[i])
+		}
+	}
+	if !capped[3].TripCapped {
+		t.Error("day 3 should be marked TripCapped")
+	}
+	if capped[3].Points != 30 {
+		t.Errorf("day 3 Points = %d, want 30 (150 trip cap - 120 already spent)", capped[3].Points)
+	}
+}
+
+func TestScoreMatchesCatalogSegmentWithinThreshold(t *testing.T) {
+	segment := straightTrack(5, 100, 0).Points
+	catalog := Catalog{Segments: []RouteSegment{
+		{Name: "Test Odcinek", Terrain: TerrainTatry, Points: 25, Track: segment},
+	}}
+
+	// An identical track should match (distance 0, well within threshold).
+	match := Score(Track{Points: segment}, catalog, Options{MatchThresholdMeters: 50})
+	if !match.UsedCatalog || match.MatchedSegment != "Test Odcinek" || match.Points != 25 {
+		t.Errorf("expected a catalog match awarding 25 pkt, got %+v", match)
+	}
+}
+
+func TestScoreDoesNotMatchCatalogSegmentBeyondThreshold(t *testing.T) {
+	segment := straightTrack(5, 100, 0).Points
+	catalog := Catalog{Segments: []RouteSegment{
+		{Name: "Test Odcinek", Terrain: TerrainTatry, Points: 25, Track: segment},
+	}}
+
+	// A track far from the segment (about 111 km away) should not match, and
+	// should fall back to the GPS-derived calculation instead.
+	farTrack := straightTrack(5, 100, 0)
+	for i := range farTrack.Points {
+		farTrack.Points[i].Lat += 1.0 // ~111km north
+	}
+
+	noMatch := Score(farTrack, catalog, Options{MatchThresholdMeters: 50})
+	if noMatch.UsedCatalog {
+		t.Errorf("expected no catalog match for a track far from every segment, got %+v", noMatch)
+	}
+}
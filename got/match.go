@@ -0,0 +1,111 @@
+package got
+
+import "math"
+
+// frechetPointBudget caps the n*m grid discreteFrechetDistance recurses
+// over. Past this size its recursion depth and O(n*m) memoization grid get
+// expensive for little benefit over a track this long, so matchCatalog
+// falls back to the cheaper (order-insensitive) Hausdorff distance instead.
+const frechetPointBudget = 20000
+
+// matchCatalog finds the catalog segment whose track best matches points,
+// returning it if that distance is within thresholdMeters. A threshold of 0
+// disables matching entirely. Matching prefers the order-sensitive discrete
+// Fréchet distance, falling back to Hausdorff distance for point sets too
+// large for Fréchet's recursion to be worth it.
+func matchCatalog(points []Point, catalog Catalog, thresholdMeters float64) (RouteSegment, bool) {
+	if thresholdMeters <= 0 {
+		return RouteSegment{}, false
+	}
+
+	best := RouteSegment{}
+	bestDist := math.Inf(1)
+	found := false
+
+	for _, seg := range catalog.Segments {
+		var d float64
+		if len(points)*len(seg.Track) > frechetPointBudget {
+			d = hausdorffDistance(points, seg.Track)
+		} else {
+			d = discreteFrechetDistance(points, seg.Track)
+		}
+		if d < bestDist {
+			bestDist = d
+			best = seg
+			found = true
+		}
+	}
+
+	if found && bestDist <= thresholdMeters {
+		return best, true
+	}
+	return RouteSegment{}, false
+}
+
+// discreteFrechetDistance computes the discrete Fréchet distance (Eiter &
+// Mannila) between two polylines, in meters. It's more faithful to how
+// "close" two hiking tracks are than Hausdorff distance alone, since it
+// respects the order points are visited in rather than just proximity.
+func discreteFrechetDistance(p, q []Point) float64 {
+	n, m := len(p), len(q)
+	if n == 0 || m == 0 {
+		return math.Inf(1)
+	}
+
+	ca := make([][]float64, n)
+	for i := range ca {
+		ca[i] = make([]float64, m)
+		for j := range ca[i] {
+			ca[i][j] = -1
+		}
+	}
+
+	var recurse func(i, j int) float64
+	recurse = func(i, j int) float64 {
+		if ca[i][j] > -1 {
+			return ca[i][j]
+		}
+
+		d := haversineMeters(p[i], q[j])
+		switch {
+		case i == 0 && j == 0:
+			ca[i][j] = d
+		case i > 0 && j == 0:
+			ca[i][j] = math.Max(recurse(i-1, 0), d)
+		case i == 0 && j > 0:
+			ca[i][j] = math.Max(recurse(0, j-1), d)
+		default:
+			ca[i][j] = math.Max(min3(recurse(i-1, j), recurse(i-1, j-1), recurse(i, j-1)), d)
+		}
+		return ca[i][j]
+	}
+
+	return recurse(n-1, m-1)
+}
+
+// hausdorffDistance computes the symmetric Hausdorff distance between two
+// point sets, in meters. Kept as a cheaper fallback metric: O(n*m) without
+// the Fréchet recursion, at the cost of ignoring point order.
+func hausdorffDistance(p, q []Point) float64 {
+	return math.Max(directedHausdorff(p, q), directedHausdorff(q, p))
+}
+
+func directedHausdorff(a, b []Point) float64 {
+	maxOfMins := 0.0
+	for _, pa := range a {
+		minDist := math.Inf(1)
+		for _, pb := range b {
+			if d := haversineMeters(pa, pb); d < minDist {
+				minDist = d
+			}
+		}
+		if minDist > maxOfMins {
+			maxOfMins = minDist
+		}
+	}
+	return maxOfMins
+}
+
+func min3(a, b, c float64) float64 {
+	return math.Min(a, math.Min(b, c))
+}
@@ -2,16 +2,26 @@ package main
 
 import (
 	"encoding/xml"
+	"flag"
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/point-blank/gpx-got-calculator/got"
 )
 
 // GPX represents the root of a GPX file
 type GPX struct {
-	XMLName xml.Name `xml:"gpx"`
-	Tracks  []Track  `xml:"trk"`
+	XMLName   xml.Name   `xml:"gpx"`
+	Tracks    []Track    `xml:"trk"`
+	Routes    []Route    `xml:"rte"`
+	Waypoints []Waypoint `xml:"wpt"`
 }
 
 // Track represents a <trk> element in GPX
@@ -27,13 +37,60 @@ type TrackSegment struct {
 	TrackPoints []TrackPoint `xml:"trkpt"`
 }
 
+// Route represents a <rte> element in GPX. Unlike a track, a route is an
+// ordered list of waypoints describing a planned path rather than a
+// recorded one, so it carries no timestamps or segments.
+type Route struct {
+	XMLName     xml.Name     `xml:"rte"`
+	Name        string       `xml:"name"`
+	RoutePoints []RoutePoint `xml:"rtept"`
+}
+
+// Waypoint represents a standalone <wpt> element in GPX.
+type Waypoint struct {
+	XMLName   xml.Name `xml:"wpt"`
+	Latitude  float64  `xml:"lat,attr"`
+	Longitude float64  `xml:"lon,attr"`
+	Elevation float64  `xml:"ele"`
+	Name      string   `xml:"name"`
+	Symbol    string   `xml:"sym"`
+}
+
+// RoutePoint represents a <rtept> element in GPX. It carries the same fields
+// as Waypoint, but encoding/xml resolves a nested struct's element name from
+// its own XMLName tag before the enclosing field tag, so Waypoint can't be
+// embedded directly here without every <rte> failing to unmarshal.
+type RoutePoint struct {
+	Latitude  float64 `xml:"lat,attr"`
+	Longitude float64 `xml:"lon,attr"`
+	Elevation float64 `xml:"ele"`
+	Name      string  `xml:"name"`
+	Symbol    string  `xml:"sym"`
+}
+
 // TrackPoint represents a <trkpt> element in GPX
 type TrackPoint struct {
-	XMLName   xml.Name  `xml:"trkpt"`
-	Latitude  float64   `xml:"lat,attr"`
-	Longitude float64   `xml:"lon,attr"`
-	Elevation float64   `xml:"ele"`
-	Time      time.Time `xml:"time"`
+	XMLName    xml.Name              `xml:"trkpt"`
+	Latitude   float64               `xml:"lat,attr"`
+	Longitude  float64               `xml:"lon,attr"`
+	Elevation  float64               `xml:"ele"`
+	Time       time.Time             `xml:"time"`
+	Extensions *TrackPointExtensions `xml:"extensions"`
+}
+
+// TrackPointExtensions holds the fitness-device metrics commonly found in the
+// Garmin TrackPointExtension namespace (used by Strava, Garmin Connect and
+// Komoot exports). The XML tags match on local name only so the
+// gpxtpx/gpxx namespace prefix used by the exporting device doesn't matter.
+type TrackPointExtensions struct {
+	HeartRate   int     `xml:"TrackPointExtension>hr"`
+	Cadence     int     `xml:"TrackPointExtension>cad"`
+	Power       float64 `xml:"TrackPointExtension>power"`
+	Temperature float64 `xml:"TrackPointExtension>atemp"`
+	// Speed is the device-reported instantaneous speed, in meters per
+	// second. Not every exporter includes it, so ComputeMovingData falls
+	// back to a GPS-derived speed when it's zero.
+	Speed float64 `xml:"TrackPointExtension>speed"`
 }
 
 // earthRadius is the average radius of the Earth in kilometers
@@ -60,41 +117,6 @@ func haversineDistance2D(p1, p2 TrackPoint) float64 {
 	return earthRadius * c // in km
 }
 
-func applyMovingAverage(points []TrackPoint, windowSize int) []TrackPoint {
-	if windowSize < 1 || len(points) == 0 {
-		return points
-	}
-	if windowSize%2 == 0 {
-		windowSize++
-	}
-
-	smoothedPoints := make([]TrackPoint, len(points))
-	halfWindow := windowSize / 2
-
-	for i := range points {
-		smoothedPoints[i] = points[i]
-
-		sumElevation := 0.0
-		count := 0
-
-		start := i - halfWindow
-		if start < 0 {
-			start = 0
-		}
-		end := i + halfWindow
-		if end >= len(points) {
-			end = len(points) - 1
-		}
-
-		for j := start; j <= end; j++ {
-			sumElevation += points[j].Elevation
-			count++
-		}
-		smoothedPoints[i].Elevation = sumElevation / float64(count)
-	}
-	return smoothedPoints
-}
-
 func calculateCumulativeAscent(points []TrackPoint, threshold float64) float64 {
 	if len(points) < 2 {
 		return 0
@@ -126,43 +148,6 @@ func calculateCumulativeAscent(points []TrackPoint, threshold float64) float64 {
 	return totalAscent
 }
 
-func applyLatLonSmoothing(points []TrackPoint, windowSize int) []TrackPoint {
-	if windowSize < 1 || len(points) == 0 {
-		return points
-	}
-	if windowSize%2 == 0 {
-		windowSize++
-	}
-
-	smoothed := make([]TrackPoint, len(points))
-	half := windowSize / 2
-
-	for i := range points {
-		sumLat, sumLon := 0.0, 0.0
-		count := 0
-
-		start := i - half
-		if start < 0 {
-			start = 0
-		}
-		end := i + half
-		if end >= len(points) {
-			end = len(points) - 1
-		}
-
-		for j := start; j <= end; j++ {
-			sumLat += points[j].Latitude
-			sumLon += points[j].Longitude
-			count++
-		}
-
-		smoothed[i] = points[i]
-		smoothed[i].Latitude = sumLat / float64(count)
-		smoothed[i].Longitude = sumLon / float64(count)
-	}
-	return smoothed
-}
-
 func groupByDay(points []TrackPoint, location *time.Location) map[string][]TrackPoint {
 	grouped := make(map[string][]TrackPoint)
 	for _, p := range points {
@@ -173,6 +158,28 @@ func groupByDay(points []TrackPoint, location *time.Location) map[string][]Track
 	return grouped
 }
 
+// toGotPoints adapts recorded track points to the lightweight got.Point used
+// by the got package's scoring/catalog-matching, which has no need for (and
+// so no dependency on) timestamps.
+func toGotPoints(points []TrackPoint) []got.Point {
+	gotPoints := make([]got.Point, len(points))
+	for i, p := range points {
+		gotPoints[i] = got.Point{Lat: p.Latitude, Lon: p.Longitude, Ele: p.Elevation}
+	}
+	return gotPoints
+}
+
+// DailyResult aggregates everything computed for a single calendar day:
+// raw distance/ascent, moving-time statistics, and the resulting GOT score.
+type DailyResult struct {
+	Distance    float64
+	Ascent      float64
+	MovingData  MovingData
+	GOTDistance int
+	GOTAscent   int
+	GOTPoints   int
+}
+
 func calculateGOTAscent(n float64) int {
 	val := n / 100.0
 	return int(math.Round(val))
@@ -186,87 +193,426 @@ func calculateDailyGOTPoints(distance int, ascent int) int {
 	return distance + ascent
 }
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run your_program_name.go <gpx_file_path>")
-		os.Exit(1)
+// waypointsToTrackPoints adapts a slice of RoutePoint (as found in a Route)
+// into TrackPoint so it can be fed through the same distance/ascent helpers
+// used for recorded tracks. Routes carry no timestamps, so Time is left zero.
+func waypointsToTrackPoints(waypoints []RoutePoint) []TrackPoint {
+	points := make([]TrackPoint, len(waypoints))
+	for i, wpt := range waypoints {
+		points[i] = TrackPoint{
+			Latitude:  wpt.Latitude,
+			Longitude: wpt.Longitude,
+			Elevation: wpt.Elevation,
+		}
 	}
+	return points
+}
 
-	filePath := os.Args[1]
-
-	gpxData, err := os.ReadFile(filePath)
-	if err != nil {
-		fmt.Printf("Error reading GPX file: %v\n", err)
-		os.Exit(1)
-	}
+// processConfig bundles the options every input file is processed with, so
+// a single value can be shared read-only across worker goroutines.
+type processConfig struct {
+	StoppedThreshold float64
+	SmoothOpts       SmoothOptions
+	AscentThreshold  float64
+	Location         *time.Location
+	// UseGotRules selects the terrain-aware, catalog-matched got.Score rules
+	// engine for route scoring instead of the legacy flat calculation.
+	UseGotRules bool
+	GotCatalog  got.Catalog
+	GotOpts     got.Options
+}
 
-	var gpx GPX
-	err = xml.Unmarshal(gpxData, &gpx)
+// fileOutcome is the result of processing a single GPX file: its per-day
+// contribution to the season, plus the stdout lines it produced. Lines are
+// buffered rather than printed directly so concurrent workers don't
+// interleave output.
+type fileOutcome struct {
+	path              string
+	results           map[string]DailyResult
+	dayPoints         map[string][]TrackPoint
+	smoothedDayPoints map[string][]TrackPoint
+	lines             []string
+	err               error
+}
 
-	if err != nil {
-		fmt.Printf("Error unmarshaling GPX XML: %v\n", err)
-		os.Exit(1)
+// processFile parses a single GPX file and computes its per-day distance,
+// ascent and moving-time contributions. It does no GOT scoring of tracks
+// itself: that happens once, after all files are merged, so a day split
+// across multiple files still gets a single consistent score. Routes, which
+// carry no dates, are scored here, one output line per route.
+func processFile(path string, cfg processConfig) fileOutcome {
+	outcome := fileOutcome{
+		path:              path,
+		results:           make(map[string]DailyResult),
+		dayPoints:         make(map[string][]TrackPoint),
+		smoothedDayPoints: make(map[string][]TrackPoint),
 	}
 
-	fmt.Printf("Successfully parsed GPX file. Found %d tracks.\n", len(gpx.Tracks))
-
-	polandLocation, err := time.LoadLocation("Europe/Warsaw")
+	gpxData, err := os.ReadFile(path)
 	if err != nil {
-		fmt.Printf("Error loading timezone 'Europe/Warsaw': %v. Using UTC.\n", err)
-		polandLocation = time.UTC // Fallback to UTC
+		outcome.err = fmt.Errorf("reading GPX file %s: %w", path, err)
+		return outcome
 	}
 
-	results := make(map[string]struct {
-		Distance float64
-		Ascent   float64
-	})
+	var gpx GPX
+	if err := xml.Unmarshal(gpxData, &gpx); err != nil {
+		outcome.err = fmt.Errorf("unmarshaling GPX XML in %s: %w", path, err)
+		return outcome
+	}
 
-	const ascentThreshold = 1.5
-	const movingAverageWindowSize = 3
+	outcome.lines = append(outcome.lines, fmt.Sprintf("Successfully parsed %s. Found %d tracks, %d routes, %d waypoints.",
+		path, len(gpx.Tracks), len(gpx.Routes), len(gpx.Waypoints)))
 
 	for _, track := range gpx.Tracks {
-		fmt.Printf("Track Name: %s\n", track.Name)
+		outcome.lines = append(outcome.lines, fmt.Sprintf("Track Name: %s", track.Name))
 		for _, segment := range track.TrackSegs {
-			grouped := groupByDay(segment.TrackPoints, polandLocation)
+			grouped := groupByDay(segment.TrackPoints, cfg.Location)
 
 			for day, pts := range grouped {
-				smoothed := applyMovingAverage(pts, movingAverageWindowSize)
-				smoothed = applyLatLonSmoothing(smoothed, movingAverageWindowSize)
+				outcome.dayPoints[day] = append(outcome.dayPoints[day], pts...)
+				smoothed := SmoothTrack(pts, cfg.SmoothOpts)
+				outcome.smoothedDayPoints[day] = append(outcome.smoothedDayPoints[day], smoothed...)
 
 				dist := 0.0
 				for i := 1; i < len(smoothed); i++ {
 					dist += haversineDistance2D(smoothed[i-1], smoothed[i])
 				}
 
-				ascent := calculateCumulativeAscent(smoothed, ascentThreshold)
+				ascent := calculateCumulativeAscent(smoothed, cfg.AscentThreshold)
+				moving := ComputeMovingData(pts, cfg.StoppedThreshold)
 
-				r := results[day]
+				r := outcome.results[day]
 				r.Distance += dist
 				r.Ascent += ascent
-				results[day] = r
+				r.MovingData.MovingTime += moving.MovingTime
+				r.MovingData.StoppedTime += moving.StoppedTime
+				r.MovingData.MovingDistance += moving.MovingDistance
+				r.MovingData.StoppedDistance += moving.StoppedDistance
+				r.MovingData.HeartRateSum += moving.HeartRateSum
+				r.MovingData.HeartRateSamples += moving.HeartRateSamples
+				if moving.MaxSpeed > r.MovingData.MaxSpeed {
+					r.MovingData.MaxSpeed = moving.MaxSpeed
+				}
+				outcome.results[day] = r
+			}
+		}
+	}
 
+	for _, route := range gpx.Routes {
+		points := waypointsToTrackPoints(route.RoutePoints)
+		smoothed := SmoothTrack(points, cfg.SmoothOpts)
+
+		dist := 0.0
+		for i := 1; i < len(smoothed); i++ {
+			dist += haversineDistance2D(smoothed[i-1], smoothed[i])
+		}
+		ascent := calculateCumulativeAscent(smoothed, cfg.AscentThreshold)
+
+		if cfg.UseGotRules {
+			score := got.Score(got.Track{Points: toGotPoints(smoothed)}, cfg.GotCatalog, cfg.GotOpts)
+			if score.UsedCatalog {
+				outcome.lines = append(outcome.lines, fmt.Sprintf("Route %q -> Distance: %.2f km, Ascent: %.0f m, matched catalog segment %q, GOT points: %d",
+					route.Name, dist, ascent, score.MatchedSegment, score.Points))
+			} else {
+				outcome.lines = append(outcome.lines, fmt.Sprintf("Route %q -> Distance: %.2f km, Ascent: %.0f m, GOT points: %d",
+					route.Name, dist, ascent, score.Points))
 			}
+			continue
 		}
+
+		gotDistance := calculateGOTDistance(dist)
+		gotAscent := calculateGOTAscent(ascent)
+		outcome.lines = append(outcome.lines, fmt.Sprintf("Route %q -> Distance: %.2f km, Ascent: %.0f m, GOT points: %d",
+			route.Name, dist, ascent, calculateDailyGOTPoints(gotDistance, gotAscent)))
 	}
 
-	fmt.Println("\n--- Results ---")
+	return outcome
+}
+
+// mergeDailyResults folds src into dst in place, day by day, so multiple
+// files contributing to the same calendar day (e.g. a GPS and a phone
+// recording the same hike) add up rather than overwrite each other.
+func mergeDailyResults(dst map[string]DailyResult, src map[string]DailyResult) {
+	for day, r := range src {
+		d := dst[day]
+		d.Distance += r.Distance
+		d.Ascent += r.Ascent
+		d.MovingData.MovingTime += r.MovingData.MovingTime
+		d.MovingData.StoppedTime += r.MovingData.StoppedTime
+		d.MovingData.MovingDistance += r.MovingData.MovingDistance
+		d.MovingData.StoppedDistance += r.MovingData.StoppedDistance
+		d.MovingData.HeartRateSum += r.MovingData.HeartRateSum
+		d.MovingData.HeartRateSamples += r.MovingData.HeartRateSamples
+		if r.MovingData.MaxSpeed > d.MovingData.MaxSpeed {
+			d.MovingData.MaxSpeed = r.MovingData.MaxSpeed
+		}
+		dst[day] = d
+	}
+}
+
+// resolveInputFiles expands each argument into a list of GPX files: a
+// directory contributes its immediate *.gpx entries, a file is used as-is.
+func resolveInputFiles(args []string) ([]string, error) {
+	var files []string
+
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", arg, err)
+		}
+
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, fmt.Errorf("reading directory %s: %w", arg, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".gpx") {
+				continue
+			}
+			files = append(files, filepath.Join(arg, entry.Name()))
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// filterByDateRange returns the subset of daily results whose date (a
+// "2006-01-02" key) falls within [since, until]. An empty bound is open.
+func filterByDateRange(results map[string]DailyResult, since, until string) map[string]DailyResult {
+	if since == "" && until == "" {
+		return results
+	}
+
+	filtered := make(map[string]DailyResult)
 	for day, r := range results {
+		if since != "" && day < since {
+			continue
+		}
+		if until != "" && day > until {
+			continue
+		}
+		filtered[day] = r
+	}
+	return filtered
+}
+
+func main() {
+	stoppedThreshold := flag.Float64("stopped-threshold", defaultStoppedSpeedThreshold,
+		"instantaneous speed (m/s) below which a segment counts as stopped rather than moving")
+	smoothMode := flag.String("smooth", string(SmoothNone),
+		"smoothing pipeline to apply: none, kalman, dp, or outlier")
+	kalmanQ := flag.Float64("kalman-q", defaultKalmanProcessVariance, "Kalman filter process variance (elevation, --smooth=kalman)")
+	kalmanR := flag.Float64("kalman-r", defaultKalmanMeasurementVariance, "Kalman filter measurement variance (elevation, --smooth=kalman)")
+	dpEpsilon := flag.Float64("dp-epsilon", defaultDouglasPeuckerEpsilon, "Douglas-Peucker simplification epsilon in meters (--smooth=dp)")
+	maxSpeedKMH := flag.Float64("max-speed-kmh", defaultMaxSpeedKMH, "speed above which a point is dropped as a GPS outlier (--smooth=outlier)")
+	outputFormat := flag.String("format", "text", "output format: text, geojson, or gpx-split")
+	outputPath := flag.String("out", "", "output path: file for geojson, directory for gpx-split (ignored for text)")
+	gotCatalogPath := flag.String("got-catalog", "", "path to an official GOT route catalog (odcinki.yaml/json) for route-matched scoring")
+	gotTerrain := flag.String("got-terrain", "", "terrain region for GOT scoring multipliers: tatry, beskidy, sudety, or nizinne")
+	gotTier := flag.String("got-tier", "", "GOT badge tier for daily point caps: popularna, mala, or duza")
+	gotMatchThreshold := flag.Float64("got-match-threshold", 150.0, "max Frechet (or, for large point sets, Hausdorff) distance (meters) to snap a track onto a catalog route segment")
+	since := flag.String("since", "", "only include days on or after this date (YYYY-MM-DD) in the season summary")
+	until := flag.String("until", "", "only include days on or before this date (YYYY-MM-DD) in the season summary")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: go run your_program_name.go [--stopped-threshold=1.0] [--smooth=none|kalman|dp|outlier] [--format=text|geojson|gpx-split] [--out=<path>] [--got-terrain=...] [--got-catalog=...] [--since=YYYY-MM-DD] [--until=YYYY-MM-DD] <gpx_file_or_dir>...")
+		os.Exit(1)
+	}
+
+	smoothOpts := SmoothOptions{
+		Mode:                      SmoothMode(*smoothMode),
+		KalmanProcessVariance:     *kalmanQ,
+		KalmanMeasurementVariance: *kalmanR,
+		DouglasPeuckerEpsilon:     *dpEpsilon,
+		MaxSpeedKMH:               *maxSpeedKMH,
+	}
+
+	useGotRules := *gotTerrain != "" || *gotCatalogPath != ""
+	gotOpts := got.Options{
+		Terrain:              got.Terrain(*gotTerrain),
+		Tier:                 got.BadgeTier(*gotTier),
+		MatchThresholdMeters: *gotMatchThreshold,
+	}
+
+	var gotCatalog got.Catalog
+	if *gotCatalogPath != "" {
+		var err error
+		gotCatalog, err = got.LoadCatalog(*gotCatalogPath)
+		if err != nil {
+			fmt.Printf("Error loading GOT catalog: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	files, err := resolveInputFiles(flag.Args())
+	if err != nil {
+		fmt.Printf("Error resolving input files: %v\n", err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Println("No .gpx files found in the given paths.")
+		os.Exit(1)
+	}
+
+	polandLocation, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		fmt.Printf("Error loading timezone 'Europe/Warsaw': %v. Using UTC.\n", err)
+		polandLocation = time.UTC // Fallback to UTC
+	}
+
+	cfg := processConfig{
+		StoppedThreshold: *stoppedThreshold,
+		SmoothOpts:       smoothOpts,
+		AscentThreshold:  1.5,
+		Location:         polandLocation,
+		UseGotRules:      useGotRules,
+		GotCatalog:       gotCatalog,
+		GotOpts:          gotOpts,
+	}
+
+	// Process files concurrently with a worker pool sized to GOMAXPROCS;
+	// outcomes are collected by index so output stays deterministic
+	// regardless of which worker finishes first.
+	outcomes := make([]fileOutcome, len(files))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+
+	for i, path := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			outcomes[i] = processFile(path, cfg)
+		}(i, path)
+	}
+	wg.Wait()
+
+	results := make(map[string]DailyResult)
+	dayPoints := make(map[string][]TrackPoint)
+	smoothedDayPoints := make(map[string][]TrackPoint)
+
+	for _, outcome := range outcomes {
+		if outcome.err != nil {
+			fmt.Printf("Error processing %s: %v (skipping this file)\n", outcome.path, outcome.err)
+			continue
+		}
+		for _, line := range outcome.lines {
+			fmt.Println(line)
+		}
+		mergeDailyResults(results, outcome.results)
+		for day, pts := range outcome.dayPoints {
+			dayPoints[day] = append(dayPoints[day], pts...)
+		}
+		for day, pts := range outcome.smoothedDayPoints {
+			smoothedDayPoints[day] = append(smoothedDayPoints[day], pts...)
+		}
+	}
+
+	results = filterByDateRange(results, *since, *until)
+
+	fmt.Println("\n--- Results ---")
+	days := make([]string, 0, len(results))
+	for day := range results {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	// The whole run of input files is treated as one trip: scoring every
+	// day up front (in the order they were hiked) lets ApplyTripCap credit
+	// earlier days first and cap the trip's running total, the same way
+	// Score caps each individual day.
+	var gotScores []got.DailyScore
+	if useGotRules {
+		gotScores = make([]got.DailyScore, len(days))
+		for i, day := range days {
+			gotScores[i] = got.Score(got.Track{Points: toGotPoints(smoothedDayPoints[day])}, gotCatalog, gotOpts)
+		}
+		gotScores = got.ApplyTripCap(gotScores, gotOpts.Tier)
+	}
+
+	for i, day := range days {
+		r := results[day]
 		fmt.Printf("%s -> Distance: %.2f km, Ascent: %.0f m\n",
 			day, r.Distance, r.Ascent)
 
-		var gotDistance = calculateGOTDistance(r.Distance)
-		var gotAscent = calculateGOTAscent(r.Ascent)
+		movingHours := r.MovingData.MovingTime / 3600.0
+		stoppedHours := r.MovingData.StoppedTime / 3600.0
+		avgMovingSpeed := 0.0
+		if r.MovingData.MovingTime > 0 {
+			avgMovingSpeed = (r.MovingData.MovingDistance * 1000.0) / r.MovingData.MovingTime
+		}
+		fmt.Printf("%s -> Moving: %.2f h (%.2f km), Stopped: %.2f h (%.2f km), Max speed: %.2f m/s, Avg moving speed: %.2f m/s\n",
+			day, movingHours, r.MovingData.MovingDistance, stoppedHours, r.MovingData.StoppedDistance,
+			r.MovingData.MaxSpeed, avgMovingSpeed)
 
-		fmt.Printf("%s -> GOT distance points: %d pkt, GOT ascent points: %d pkt\n",
-			day, gotDistance, gotAscent)
+		if r.MovingData.HeartRateSamples > 0 {
+			avgHeartRate := r.MovingData.HeartRateSum / float64(r.MovingData.HeartRateSamples)
+			fmt.Printf("%s -> Avg heart rate: %.0f bpm\n", day, avgHeartRate)
+		}
 
-		var gotSum = calculateDailyGOTPoints(gotDistance, gotAscent)
-		if gotSum >= 50 {
-			fmt.Printf("%s -> GOT points LIMIT achieved %d\n", day, 50)
+		var gotDistance, gotAscent, gotSum int
+		if useGotRules {
+			score := gotScores[i]
+			gotDistance = score.DistancePoints
+			gotAscent = score.AscentPoints
+			gotSum = score.Points
+
+			fmt.Printf("%s -> GOT distance points: %d pkt, GOT ascent points: %d pkt\n",
+				day, gotDistance, gotAscent)
+			if score.UsedCatalog {
+				fmt.Printf("%s -> Matched catalog segment %q, awarding %d pkt\n", day, score.MatchedSegment, score.RawPoints)
+			}
+			switch {
+			case score.Capped:
+				fmt.Printf("%s -> GOT points LIMIT achieved %d (daily cap)\n", day, score.DailyCap)
+			case score.TripCapped:
+				fmt.Printf("%s -> GOT points LIMIT achieved %d (trip cap)\n", day, gotSum)
+			default:
+				fmt.Printf("%s -> GOT points achieved %d\n", day, gotSum)
+			}
 		} else {
-			fmt.Printf("%s -> GOT points achieved %d\n", day, gotSum)
+			gotDistance = calculateGOTDistance(r.Distance)
+			gotAscent = calculateGOTAscent(r.Ascent)
+
+			fmt.Printf("%s -> GOT distance points: %d pkt, GOT ascent points: %d pkt\n",
+				day, gotDistance, gotAscent)
+
+			gotSum = calculateDailyGOTPoints(gotDistance, gotAscent)
+			if gotSum >= 50 {
+				fmt.Printf("%s -> GOT points LIMIT achieved %d\n", day, 50)
+			} else {
+				fmt.Printf("%s -> GOT points achieved %d\n", day, gotSum)
+			}
 		}
 
+		r.GOTDistance = gotDistance
+		r.GOTAscent = gotAscent
+		r.GOTPoints = gotSum
+		results[day] = r
 	}
 
+	if err := writeOutput(*outputFormat, *outputPath, results, dayPoints); err != nil {
+		fmt.Printf("Error writing %s output: %v\n", *outputFormat, err)
+		os.Exit(1)
+	}
+
+	season := AggregateSeason(results)
+	fmt.Println("\n--- Season Summary ---")
+	fmt.Printf("Total points: %d across %d active day(s) (%.2f km, %.0f m ascent)\n",
+		season.TotalPoints, season.DaysActive, season.CumulativeDistanceKM, season.CumulativeAscentM)
+	for _, tier := range season.TierProgress {
+		status := "not yet achieved"
+		if tier.Achieved {
+			status = "achieved"
+		}
+		fmt.Printf("Badge %q (%d pkt): %s\n", tier.Tier, tier.RequiredPoints, status)
+	}
 }
@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestMergeDailyResultsAddsAcrossFiles(t *testing.T) {
+	dst := map[string]DailyResult{
+		"2026-06-01": {
+			Distance: 10,
+			Ascent:   400,
+			MovingData: MovingData{
+				MovingTime:     3600,
+				MovingDistance: 8,
+				MaxSpeed:       2.0,
+			},
+		},
+	}
+	src := map[string]DailyResult{
+		// Same day, contributed by a second file (e.g. a phone recording
+		// alongside a GPS watch): should add, not overwrite.
+		"2026-06-01": {
+			Distance: 5,
+			Ascent:   100,
+			MovingData: MovingData{
+				MovingTime:     1800,
+				MovingDistance: 4,
+				MaxSpeed:       3.0,
+			},
+		},
+		// A day only present in src: should be added as a new entry.
+		"2026-06-02": {
+			Distance: 7,
+			Ascent:   200,
+		},
+	}
+
+	mergeDailyResults(dst, src)
+
+	merged := dst["2026-06-01"]
+	if merged.Distance != 15 {
+		t.Errorf("Distance = %v, want 15 (10+5)", merged.Distance)
+	}
+	if merged.Ascent != 500 {
+		t.Errorf("Ascent = %v, want 500 (400+100)", merged.Ascent)
+	}
+	if merged.MovingData.MovingTime != 5400 {
+		t.Errorf("MovingTime = %v, want 5400 (3600+1800)", merged.MovingData.MovingTime)
+	}
+	if merged.MovingData.MovingDistance != 12 {
+		t.Errorf("MovingDistance = %v, want 12 (8+4)", merged.MovingData.MovingDistance)
+	}
+	if merged.MovingData.MaxSpeed != 3.0 {
+		t.Errorf("MaxSpeed = %v, want 3.0 (max of 2.0 and 3.0, not summed)", merged.MovingData.MaxSpeed)
+	}
+
+	if _, ok := dst["2026-06-02"]; !ok {
+		t.Fatal("expected a new day from src to be added to dst")
+	}
+	if dst["2026-06-02"].Distance != 7 {
+		t.Errorf("2026-06-02 Distance = %v, want 7", dst["2026-06-02"].Distance)
+	}
+}
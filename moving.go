@@ -0,0 +1,88 @@
+package main
+
+import "math"
+
+// defaultStoppedSpeedThreshold is the instantaneous speed, in m/s, below
+// which a point-to-point segment is considered stopped rather than moving.
+// 1.0 m/s is roughly a slow amble, comfortably below hiking pace.
+const defaultStoppedSpeedThreshold = 1.0
+
+// MovingData summarizes how much of a day's track was actually spent moving
+// versus stopped (lunch breaks, photo stops, GPS drift while stationary),
+// plus the resulting speed and heart-rate statistics.
+type MovingData struct {
+	MovingTime      float64 // seconds
+	StoppedTime     float64 // seconds
+	MovingDistance  float64 // km
+	StoppedDistance float64 // km
+	MaxSpeed        float64 // m/s
+	AvgMovingSpeed  float64 // m/s
+	// HeartRateSum/HeartRateSamples are accumulators (rather than a
+	// precomputed average) so multiple days' worth of samples can be merged
+	// by simple addition before the average is taken once, at print time.
+	HeartRateSum     float64 // bpm, summed across samples with HR extension data
+	HeartRateSamples int
+}
+
+// distance3D returns the distance in meters between two track points,
+// combining the haversine great-circle distance with the elevation delta.
+func distance3D(p1, p2 TrackPoint) float64 {
+	flat := haversineDistance2D(p1, p2) * 1000.0 // km -> m
+	dEle := p2.Elevation - p1.Elevation
+	return math.Sqrt(flat*flat + dEle*dEle)
+}
+
+// ComputeMovingData walks consecutive point pairs and buckets each segment
+// into "moving" or "stopped" based on its instantaneous speed against
+// stoppedSpeedThreshold (m/s). Segments with a non-positive time delta
+// (out-of-order or duplicate timestamps) are skipped entirely so they can't
+// distort the speed statistics. When a point carries a device-reported
+// speed (the Garmin TrackPointExtension used by most GPS watches), that
+// reading is used in place of the GPS-derived speed: it comes from the
+// device's own Doppler/accelerometer fusion and is far less noisy than
+// distance-over-time on consumer GPS hardware, especially over a short
+// segment. Heart rate, where present, is summed into HeartRateSum and
+// counted in HeartRateSamples across all points; the average itself is not
+// computed here.
+func ComputeMovingData(points []TrackPoint, stoppedSpeedThreshold float64) MovingData {
+	var md MovingData
+
+	for i := 1; i < len(points); i++ {
+		p1, p2 := points[i-1], points[i]
+		dt := p2.Time.Sub(p1.Time).Seconds()
+		if dt <= 0 {
+			continue
+		}
+
+		distMeters := distance3D(p1, p2)
+		speed := distMeters / dt
+		if p2.Extensions != nil && p2.Extensions.Speed > 0 {
+			speed = p2.Extensions.Speed
+		}
+
+		if speed < stoppedSpeedThreshold {
+			md.StoppedTime += dt
+			md.StoppedDistance += distMeters / 1000.0
+			continue
+		}
+
+		md.MovingTime += dt
+		md.MovingDistance += distMeters / 1000.0
+		if speed > md.MaxSpeed {
+			md.MaxSpeed = speed
+		}
+	}
+
+	if md.MovingTime > 0 {
+		md.AvgMovingSpeed = (md.MovingDistance * 1000.0) / md.MovingTime
+	}
+
+	for _, p := range points {
+		if p.Extensions != nil && p.Extensions.HeartRate > 0 {
+			md.HeartRateSum += float64(p.Extensions.HeartRate)
+			md.HeartRateSamples++
+		}
+	}
+
+	return md
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeMovingDataBucketsBySpeedThreshold(t *testing.T) {
+	base := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	points := []TrackPoint{
+		// ~0.1 m/s over 10s: below the 1.0 m/s threshold, stopped.
+		{Latitude: 49.00000, Longitude: 19.00000, Time: base},
+		{Latitude: 49.00000, Longitude: 19.00001, Time: base.Add(10 * time.Second)},
+		// ~3.3 m/s over 10s: above the threshold, moving.
+		{Latitude: 49.00025, Longitude: 19.00025, Time: base.Add(20 * time.Second)},
+	}
+
+	md := ComputeMovingData(points, defaultStoppedSpeedThreshold)
+
+	if md.StoppedTime != 10 {
+		t.Errorf("StoppedTime = %v, want 10", md.StoppedTime)
+	}
+	if md.MovingTime != 10 {
+		t.Errorf("MovingTime = %v, want 10", md.MovingTime)
+	}
+	if md.MaxSpeed <= defaultStoppedSpeedThreshold {
+		t.Errorf("MaxSpeed = %v, want > %v (the moving segment)", md.MaxSpeed, defaultStoppedSpeedThreshold)
+	}
+}
+
+func TestComputeMovingDataSkipsNonPositiveTimeDeltas(t *testing.T) {
+	base := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	points := []TrackPoint{
+		{Latitude: 49.0000, Longitude: 19.0000, Time: base},
+		// Duplicate timestamp: dt == 0, must be skipped rather than
+		// producing an infinite or NaN speed.
+		{Latitude: 49.0010, Longitude: 19.0010, Time: base},
+		// Out-of-order timestamp: dt < 0, must also be skipped.
+		{Latitude: 49.0020, Longitude: 19.0020, Time: base.Add(-1 * time.Second)},
+	}
+
+	md := ComputeMovingData(points, defaultStoppedSpeedThreshold)
+
+	if md.MovingTime != 0 || md.StoppedTime != 0 {
+		t.Errorf("expected no time accounted for non-positive dt segments, got MovingTime=%v StoppedTime=%v",
+			md.MovingTime, md.StoppedTime)
+	}
+}
+
+func TestComputeMovingDataPrefersDeviceReportedSpeed(t *testing.T) {
+	base := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	points := []TrackPoint{
+		// GPS-derived speed here is ~0 m/s (no lat/lon movement), which
+		// would bucket the segment as stopped. A device-reported speed
+		// above the threshold should override that and bucket it moving.
+		{Latitude: 49.0000, Longitude: 19.0000, Time: base},
+		{Latitude: 49.0000, Longitude: 19.0000, Time: base.Add(1 * time.Second), Extensions: &TrackPointExtensions{Speed: 5.0}},
+	}
+
+	md := ComputeMovingData(points, defaultStoppedSpeedThreshold)
+
+	if md.StoppedTime != 0 {
+		t.Errorf("StoppedTime = %v, want 0 (device speed should mark this segment moving)", md.StoppedTime)
+	}
+	if md.MovingTime != 1 {
+		t.Errorf("MovingTime = %v, want 1", md.MovingTime)
+	}
+	if md.MaxSpeed != 5.0 {
+		t.Errorf("MaxSpeed = %v, want 5.0 (device-reported speed)", md.MaxSpeed)
+	}
+}
+
+func TestComputeMovingDataAccumulatesHeartRateAcrossSamples(t *testing.T) {
+	base := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	points := []TrackPoint{
+		{Latitude: 49.0000, Longitude: 19.0000, Time: base, Extensions: &TrackPointExtensions{HeartRate: 100}},
+		{Latitude: 49.0001, Longitude: 19.0001, Time: base.Add(1 * time.Second), Extensions: &TrackPointExtensions{HeartRate: 120}},
+		// No extensions at all: should not count as a sample.
+		{Latitude: 49.0002, Longitude: 19.0002, Time: base.Add(2 * time.Second)},
+	}
+
+	md := ComputeMovingData(points, defaultStoppedSpeedThreshold)
+
+	if md.HeartRateSamples != 2 {
+		t.Fatalf("HeartRateSamples = %d, want 2", md.HeartRateSamples)
+	}
+	if md.HeartRateSum != 220 {
+		t.Errorf("HeartRateSum = %v, want 220", md.HeartRateSum)
+	}
+}
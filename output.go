@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// geoJSONFeatureCollection, geoJSONFeature and geoJSONGeometry mirror just
+// enough of the GeoJSON spec (RFC 7946) to describe one LineString per day;
+// they exist purely to give WriteGeoJSON a stable json.Marshal shape.
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates [][]float64 `json:"coordinates"`
+}
+
+// WriteGeoJSON writes a GeoJSON FeatureCollection with one LineString
+// feature per day in results, ordered by date, so the output is stable
+// across runs. Each feature's coordinates come from dayPoints; results
+// supplies the date/distance_km/ascent_m/got_points properties.
+func WriteGeoJSON(w io.Writer, results map[string]DailyResult, dayPoints map[string][]TrackPoint) error {
+	days := make([]string, 0, len(results))
+	for day := range results {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	fc := geoJSONFeatureCollection{Type: "FeatureCollection"}
+
+	for _, day := range days {
+		r := results[day]
+		pts := dayPoints[day]
+
+		coords := make([][]float64, len(pts))
+		for i, p := range pts {
+			coords[i] = []float64{p.Longitude, p.Latitude}
+		}
+
+		fc.Features = append(fc.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONGeometry{
+				Type:        "LineString",
+				Coordinates: coords,
+			},
+			Properties: map[string]interface{}{
+				"date":        day,
+				"distance_km": r.Distance,
+				"ascent_m":    r.Ascent,
+				"got_points":  r.GOTPoints,
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(fc)
+}
+
+// WriteGPXPerDay splits grouped track points into one minimal GPX file per
+// day, written to dir as <date>.gpx, for feeding into per-day map viewers.
+func WriteGPXPerDay(dir string, grouped map[string][]TrackPoint) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	for day, pts := range grouped {
+		dayGPX := GPX{
+			XMLName: xml.Name{Local: "gpx"},
+			Tracks: []Track{
+				{
+					XMLName: xml.Name{Local: "trk"},
+					Name:    day,
+					TrackSegs: []TrackSegment{
+						{
+							XMLName:     xml.Name{Local: "trkseg"},
+							TrackPoints: pts,
+						},
+					},
+				},
+			},
+		}
+
+		data, err := xml.MarshalIndent(dayGPX, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling GPX for %s: %w", day, err)
+		}
+
+		path := filepath.Join(dir, day+".gpx")
+		if err := os.WriteFile(path, append([]byte(xml.Header), data...), 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// writeOutput dispatches to the output format selected by --format. "text"
+// is a no-op here since the summary is already printed to stdout as results
+// are computed; "geojson" and "gpx-split" are the pipeline-friendly formats
+// added for map rendering.
+func writeOutput(format, outPath string, results map[string]DailyResult, dayPoints map[string][]TrackPoint) error {
+	switch format {
+	case "text", "":
+		return nil
+	case "geojson":
+		w := io.Writer(os.Stdout)
+		if outPath != "" {
+			f, err := os.Create(outPath)
+			if err != nil {
+				return fmt.Errorf("creating %s: %w", outPath, err)
+			}
+			defer f.Close()
+			w = f
+		}
+		return WriteGeoJSON(w, results, dayPoints)
+	case "gpx-split":
+		if outPath == "" {
+			return fmt.Errorf("--out is required for --format=gpx-split")
+		}
+		return WriteGPXPerDay(outPath, dayPoints)
+	default:
+		return fmt.Errorf("unknown format %q (want text, geojson, or gpx-split)", format)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteGeoJSONFeatureCoordinatesAndProperties(t *testing.T) {
+	results := map[string]DailyResult{
+		"2026-06-01": {Distance: 12.5, Ascent: 600, GOTPoints: 18},
+	}
+	dayPoints := map[string][]TrackPoint{
+		"2026-06-01": {
+			{Latitude: 49.1, Longitude: 19.1},
+			{Latitude: 49.2, Longitude: 19.2},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, results, dayPoints); err != nil {
+		t.Fatalf("WriteGeoJSON: %v", err)
+	}
+
+	var fc geoJSONFeatureCollection
+	if err := json.Unmarshal(buf.Bytes(), &fc); err != nil {
+		t.Fatalf("unmarshaling output: %v", err)
+	}
+
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 1 {
+		t.Fatalf("unexpected feature collection: %+v", fc)
+	}
+
+	feature := fc.Features[0]
+	if feature.Geometry.Type != "LineString" {
+		t.Errorf("Geometry.Type = %q, want LineString", feature.Geometry.Type)
+	}
+	wantCoords := [][]float64{{19.1, 49.1}, {19.2, 49.2}}
+	if len(feature.Geometry.Coordinates) != len(wantCoords) {
+		t.Fatalf("got %d coordinates, want %d", len(feature.Geometry.Coordinates), len(wantCoords))
+	}
+	for i, c := range wantCoords {
+		if feature.Geometry.Coordinates[i][0] != c[0] || feature.Geometry.Coordinates[i][1] != c[1] {
+			t.Errorf("coordinate %d = %v, want %v (lon, lat order)", i, feature.Geometry.Coordinates[i], c)
+		}
+	}
+
+	if feature.Properties["date"] != "2026-06-01" {
+		t.Errorf("date property = %v, want 2026-06-01", feature.Properties["date"])
+	}
+	if feature.Properties["got_points"] != float64(18) {
+		t.Errorf("got_points property = %v, want 18", feature.Properties["got_points"])
+	}
+}
+
+func TestWriteGPXPerDaySplitsIntoOneFilePerDay(t *testing.T) {
+	dir := t.TempDir()
+	grouped := map[string][]TrackPoint{
+		"2026-06-01": {{Latitude: 49.1, Longitude: 19.1, Elevation: 1000}},
+		"2026-06-02": {{Latitude: 49.2, Longitude: 19.2, Elevation: 1100}},
+	}
+
+	if err := WriteGPXPerDay(dir, grouped); err != nil {
+		t.Fatalf("WriteGPXPerDay: %v", err)
+	}
+
+	for day, pts := range grouped {
+		path := filepath.Join(dir, day+".gpx")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading %s: %v", path, err)
+		}
+
+		var gpx GPX
+		if err := xml.Unmarshal(data, &gpx); err != nil {
+			t.Fatalf("unmarshaling %s: %v", path, err)
+		}
+		if len(gpx.Tracks) != 1 || gpx.Tracks[0].Name != day {
+			t.Fatalf("%s: unexpected track %+v", path, gpx.Tracks)
+		}
+		if len(gpx.Tracks[0].TrackSegs) != 1 || len(gpx.Tracks[0].TrackSegs[0].TrackPoints) != 1 {
+			t.Fatalf("%s: unexpected segment shape %+v", path, gpx.Tracks[0].TrackSegs)
+		}
+		got := gpx.Tracks[0].TrackSegs[0].TrackPoints[0]
+		if got.Latitude != pts[0].Latitude || got.Longitude != pts[0].Longitude {
+			t.Errorf("%s: point = %+v, want %+v", path, got, pts[0])
+		}
+	}
+}
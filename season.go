@@ -0,0 +1,61 @@
+package main
+
+// qualifyingDailyPoints is the minimum a single day must score before it
+// counts as an "active" GOT day in the season summary.
+const qualifyingDailyPoints = 5
+
+// BadgeTierProgress reports how a season's total points compare to a single
+// GOT badge tier's requirement.
+type BadgeTierProgress struct {
+	Tier           string
+	RequiredPoints int
+	Achieved       bool
+}
+
+// badgeTiers lists the GOT badge tiers in ascending point order, per the
+// PTTK regulations (popularna through the silver/duża srebrna tier).
+var badgeTiers = []struct {
+	Name   string
+	Points int
+}{
+	{"popularna", 60},
+	{"mała brązowa", 120},
+	{"srebrna", 240},
+	{"złota", 360},
+	{"duża srebrna", 450},
+}
+
+// SeasonReport aggregates every day processed in a run into season-level
+// totals and progress toward each GOT badge tier.
+type SeasonReport struct {
+	TotalPoints          int
+	DaysActive           int
+	CumulativeDistanceKM float64
+	CumulativeAscentM    float64
+	TierProgress         []BadgeTierProgress
+}
+
+// AggregateSeason sums daily results into a SeasonReport and evaluates
+// badge-tier progress against the season total.
+func AggregateSeason(daily map[string]DailyResult) SeasonReport {
+	var report SeasonReport
+
+	for _, r := range daily {
+		report.TotalPoints += r.GOTPoints
+		report.CumulativeDistanceKM += r.Distance
+		report.CumulativeAscentM += r.Ascent
+		if r.GOTPoints >= qualifyingDailyPoints {
+			report.DaysActive++
+		}
+	}
+
+	for _, tier := range badgeTiers {
+		report.TierProgress = append(report.TierProgress, BadgeTierProgress{
+			Tier:           tier.Name,
+			RequiredPoints: tier.Points,
+			Achieved:       report.TotalPoints >= tier.Points,
+		})
+	}
+
+	return report
+}
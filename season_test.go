@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestAggregateSeasonCountsActiveDaysAndTotals(t *testing.T) {
+	daily := map[string]DailyResult{
+		"2026-06-01": {Distance: 10, Ascent: 500, GOTPoints: 20}, // qualifies (>= 5)
+		"2026-06-02": {Distance: 2, Ascent: 0, GOTPoints: 2},     // too few points, doesn't qualify
+		"2026-06-03": {Distance: 15, Ascent: 800, GOTPoints: 45}, // qualifies
+	}
+
+	report := AggregateSeason(daily)
+
+	if report.TotalPoints != 67 {
+		t.Errorf("TotalPoints = %d, want 67", report.TotalPoints)
+	}
+	if report.DaysActive != 2 {
+		t.Errorf("DaysActive = %d, want 2", report.DaysActive)
+	}
+	if report.CumulativeDistanceKM != 27 {
+		t.Errorf("CumulativeDistanceKM = %v, want 27", report.CumulativeDistanceKM)
+	}
+	if report.CumulativeAscentM != 1300 {
+		t.Errorf("CumulativeAscentM = %v, want 1300", report.CumulativeAscentM)
+	}
+}
+
+func TestAggregateSeasonTierProgress(t *testing.T) {
+	// 130 total points: past "popularna" (60) and "mała brązowa" (120), but
+	// short of "srebrna" (240).
+	daily := map[string]DailyResult{
+		"2026-06-01": {GOTPoints: 130},
+	}
+
+	report := AggregateSeason(daily)
+
+	want := map[string]bool{
+		"popularna":    true,
+		"mała brązowa": true,
+		"srebrna":      false,
+		"złota":        false,
+		"duża srebrna": false,
+	}
+
+	if len(report.TierProgress) != len(want) {
+		t.Fatalf("got %d tiers, want %d", len(report.TierProgress), len(want))
+	}
+	for _, tp := range report.TierProgress {
+		if tp.Achieved != want[tp.Tier] {
+			t.Errorf("tier %q Achieved = %v, want %v", tp.Tier, tp.Achieved, want[tp.Tier])
+		}
+	}
+}
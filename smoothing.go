@@ -0,0 +1,188 @@
+package main
+
+import "math"
+
+// SmoothMode selects which noise-reduction pass SmoothTrack applies.
+type SmoothMode string
+
+const (
+	SmoothNone           SmoothMode = "none"
+	SmoothKalman         SmoothMode = "kalman"
+	SmoothDouglasPeucker SmoothMode = "dp"
+	SmoothOutlier        SmoothMode = "outlier"
+)
+
+// Defaults mirror typical hiking-activity noise: a lenient Kalman filter on
+// elevation, a few-meter Douglas-Peucker epsilon to drop GPS jitter, and a
+// 40 km/h ceiling above which a point is almost certainly a GPS glitch
+// rather than an actual hiker.
+const (
+	defaultKalmanProcessVariance     = 0.01
+	defaultKalmanMeasurementVariance = 4.0
+	defaultDouglasPeuckerEpsilon     = 5.0
+	defaultMaxSpeedKMH               = 40.0
+)
+
+// SmoothOptions configures SmoothTrack. Only the fields relevant to Mode are
+// used; the rest are ignored.
+type SmoothOptions struct {
+	Mode                      SmoothMode
+	KalmanProcessVariance     float64 // Q
+	KalmanMeasurementVariance float64 // R
+	DouglasPeuckerEpsilon     float64 // meters
+	MaxSpeedKMH               float64
+}
+
+// SmoothTrack replaces the old fixed-window moving-average smoothing with a
+// pipeline of purpose-built noise reducers, selected by opts.Mode:
+//
+//   - SmoothKalman applies a 1-D Kalman filter to elevation, which tracks
+//     real climbs instead of flattening them the way a moving average does.
+//   - SmoothDouglasPeucker simplifies the lat/lon polyline, dropping points
+//     that don't meaningfully change the path so summed distance isn't
+//     inflated by GPS jitter.
+//   - SmoothOutlier drops points implying a speed no hiker can sustain.
+//
+// SmoothNone (or an unrecognized mode) returns points unchanged.
+func SmoothTrack(points []TrackPoint, opts SmoothOptions) []TrackPoint {
+	switch opts.Mode {
+	case SmoothKalman:
+		return kalmanSmoothElevation(points, opts.KalmanProcessVariance, opts.KalmanMeasurementVariance)
+	case SmoothDouglasPeucker:
+		return douglasPeucker(points, opts.DouglasPeuckerEpsilon)
+	case SmoothOutlier:
+		return removeSpeedOutliers(points, opts.MaxSpeedKMH)
+	default:
+		return points
+	}
+}
+
+// kalmanSmoothElevation runs a 1-D Kalman filter over elevation only;
+// latitude, longitude and time pass through unchanged. Q is the process
+// variance (how much we expect true elevation to drift between samples),
+// R is the measurement variance (how noisy the GPS/barometric reading is).
+func kalmanSmoothElevation(points []TrackPoint, q, r float64) []TrackPoint {
+	if len(points) == 0 {
+		return points
+	}
+
+	out := make([]TrackPoint, len(points))
+	copy(out, points)
+
+	x := points[0].Elevation
+	p := 1.0
+
+	for i := range out {
+		if i > 0 {
+			// predict
+			p = p + q
+		}
+		// update
+		k := p / (p + r)
+		z := points[i].Elevation
+		x = x + k*(z-x)
+		p = (1 - k) * p
+
+		out[i].Elevation = x
+	}
+
+	return out
+}
+
+// flatProjection converts a point to planar meters relative to origin using
+// an equirectangular approximation. Good enough for the short distances
+// Douglas-Peucker operates over; not meant for large-scale navigation.
+func flatProjection(p, origin TrackPoint) (x, y float64) {
+	originLatRad := toRadians(origin.Latitude)
+	x = toRadians(p.Longitude-origin.Longitude) * math.Cos(originLatRad) * earthRadius * 1000
+	y = toRadians(p.Latitude-origin.Latitude) * earthRadius * 1000
+	return x, y
+}
+
+// perpendicularDistanceMeters returns the distance, in meters, from point to
+// the infinite line through lineStart and lineEnd (or to lineStart when the
+// two coincide).
+func perpendicularDistanceMeters(point, lineStart, lineEnd TrackPoint) float64 {
+	px, py := flatProjection(point, lineStart)
+	ex, ey := flatProjection(lineEnd, lineStart)
+
+	segLenSq := ex*ex + ey*ey
+	if segLenSq == 0 {
+		return math.Hypot(px, py)
+	}
+
+	// distance from point to the infinite line through lineStart/lineEnd
+	return math.Abs(px*ey-py*ex) / math.Sqrt(segLenSq)
+}
+
+// douglasPeucker simplifies the lat/lon polyline, dropping interior points
+// that lie within epsilon meters of the line between their neighbors.
+// Endpoints are always kept; elevation and time of kept points are
+// untouched.
+func douglasPeucker(points []TrackPoint, epsilon float64) []TrackPoint {
+	if len(points) < 3 {
+		out := make([]TrackPoint, len(points))
+		copy(out, points)
+		return out
+	}
+
+	maxDist := 0.0
+	maxIndex := 0
+	last := len(points) - 1
+
+	for i := 1; i < last; i++ {
+		d := perpendicularDistanceMeters(points[i], points[0], points[last])
+		if d > maxDist {
+			maxDist = d
+			maxIndex = i
+		}
+	}
+
+	if maxDist <= epsilon {
+		return []TrackPoint{points[0], points[last]}
+	}
+
+	left := douglasPeucker(points[:maxIndex+1], epsilon)
+	right := douglasPeucker(points[maxIndex:], epsilon)
+
+	// Build a fresh slice rather than append(left[:len(left)-1], right...).
+	// Together with the copy in the len(points) < 3 base case, this means
+	// every slice douglasPeucker returns has its own backing array, so the
+	// result never aliases, and never writes into, the caller's points.
+	out := make([]TrackPoint, 0, len(left)-1+len(right))
+	out = append(out, left[:len(left)-1]...)
+	out = append(out, right...)
+	return out
+}
+
+// removeSpeedOutliers drops points whose instantaneous speed from the last
+// retained point exceeds maxSpeedKMH, a common GPS glitch (a single point
+// jumping hundreds of meters). Segments with a non-positive time delta are
+// kept as-is since speed can't be evaluated.
+func removeSpeedOutliers(points []TrackPoint, maxSpeedKMH float64) []TrackPoint {
+	if len(points) == 0 {
+		return points
+	}
+
+	maxSpeedMS := maxSpeedKMH * 1000.0 / 3600.0
+
+	out := make([]TrackPoint, 0, len(points))
+	out = append(out, points[0])
+
+	for i := 1; i < len(points); i++ {
+		last := out[len(out)-1]
+		dt := points[i].Time.Sub(last.Time).Seconds()
+		if dt <= 0 {
+			out = append(out, points[i])
+			continue
+		}
+
+		speed := distance3D(last, points[i]) / dt
+		if speed > maxSpeedMS {
+			continue
+		}
+		out = append(out, points[i])
+	}
+
+	return out
+}
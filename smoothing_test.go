@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSmoothTrackDouglasPeuckerDoesNotMutateInput(t *testing.T) {
+	base := time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)
+	points := []TrackPoint{
+		{Latitude: 49.0000, Longitude: 19.0000, Elevation: 1000, Time: base},
+		{Latitude: 49.0001, Longitude: 19.0001, Elevation: 1001, Time: base.Add(1 * time.Second)},
+		{Latitude: 49.0002, Longitude: 19.0002, Elevation: 1002, Time: base.Add(2 * time.Second)},
+		{Latitude: 49.0003, Longitude: 19.0003, Elevation: 1003, Time: base.Add(3 * time.Second)},
+		{Latitude: 49.0500, Longitude: 19.0500, Elevation: 1100, Time: base.Add(4 * time.Second)},
+	}
+
+	want := make([]TrackPoint, len(points))
+	copy(want, points)
+
+	SmoothTrack(points, SmoothOptions{Mode: SmoothDouglasPeucker, DouglasPeuckerEpsilon: 5.0})
+
+	for i := range points {
+		if points[i] != want[i] {
+			t.Fatalf("point %d mutated by SmoothTrack(dp): got %+v, want %+v", i, points[i], want[i])
+		}
+	}
+}